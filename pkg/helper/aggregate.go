@@ -0,0 +1,203 @@
+package helper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxAggregateMessageEntries caps how many offending manifests are listed by name in an
+// aggregated condition's message, keeping it readable on ManifestWorks with many manifests.
+const maxAggregateMessageEntries = 10
+
+// MergeStrategy selects how AggregateManifestConditions rolls per-manifest StatusConditions of a
+// given type up into a single StatusCondition.
+type MergeStrategy string
+
+const (
+	// AllTrueMergeStrategy reports True only if every manifest reports True for the target
+	// condition type; otherwise it reports False, listing the manifests that do not.
+	AllTrueMergeStrategy MergeStrategy = "AllTrue"
+	// AnyFalseMergeStrategy reports False as soon as any manifest reports False for the target
+	// condition type, listing the offending manifests; otherwise it reports True.
+	AnyFalseMergeStrategy MergeStrategy = "AnyFalse"
+	// PriorityMergeStrategy reports the status with the highest priority among the manifests, as
+	// ranked by AggregateOptions.Priority.
+	PriorityMergeStrategy MergeStrategy = "Priority"
+)
+
+// StatusPriority ranks metav1.ConditionStatus values from highest to lowest priority, for use
+// with PriorityMergeStrategy.
+type StatusPriority []metav1.ConditionStatus
+
+// DefaultStatusPriority ranks Unknown above False above True, so a single manifest in an unknown
+// or failing state keeps the aggregated condition from reporting True.
+var DefaultStatusPriority = StatusPriority{
+	metav1.ConditionUnknown,
+	metav1.ConditionFalse,
+	metav1.ConditionTrue,
+}
+
+// AggregateOptions configures AggregateManifestConditions.
+type AggregateOptions struct {
+	// Strategy selects the merge strategy. It defaults to AllTrueMergeStrategy when empty.
+	Strategy MergeStrategy
+	// Priority is consulted only when Strategy is PriorityMergeStrategy. It defaults to
+	// DefaultStatusPriority when unset.
+	Priority StatusPriority
+}
+
+// manifestResult identifies a manifest that contributed to an aggregated condition's message.
+type manifestResult struct {
+	ordinal  int32
+	resource string
+}
+
+func (m manifestResult) String() string {
+	return fmt.Sprintf("[ordinal=%d resource=%s]", m.ordinal, m.resource)
+}
+
+// AggregateManifestConditions rolls the StatusCondition of type conditionType from each of
+// manifestConditions up into a single StatusCondition suitable for ManifestWorkStatus.Conditions.
+// A manifest that does not report conditionType at all is treated as Unknown.
+func AggregateManifestConditions(conditionType string, manifestConditions []workapiv1.ManifestCondition, opts AggregateOptions) workapiv1.StatusCondition {
+	switch opts.Strategy {
+	case AnyFalseMergeStrategy:
+		return aggregateAnyFalse(conditionType, manifestConditions)
+	case PriorityMergeStrategy:
+		priority := opts.Priority
+		if len(priority) == 0 {
+			priority = DefaultStatusPriority
+		}
+		return aggregatePriority(conditionType, manifestConditions, priority)
+	default:
+		return aggregateAllTrue(conditionType, manifestConditions)
+	}
+}
+
+func aggregateAllTrue(conditionType string, manifestConditions []workapiv1.ManifestCondition) workapiv1.StatusCondition {
+	var failing []manifestResult
+	for _, mc := range manifestConditions {
+		if status := manifestConditionStatus(mc, conditionType); status != metav1.ConditionTrue {
+			failing = append(failing, manifestResult{mc.ResourceMeta.Ordinal, mc.ResourceMeta.Resource})
+		}
+	}
+
+	if len(failing) == 0 {
+		return workapiv1.StatusCondition{
+			Type:    conditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  fmt.Sprintf("%sManifestsTrue", conditionType),
+			Message: fmt.Sprintf("All %d manifests are %s", len(manifestConditions), conditionType),
+		}
+	}
+
+	return workapiv1.StatusCondition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  fmt.Sprintf("%sManifestFailed", conditionType),
+		Message: summarizeManifests("failed", len(manifestConditions), failing),
+	}
+}
+
+func aggregateAnyFalse(conditionType string, manifestConditions []workapiv1.ManifestCondition) workapiv1.StatusCondition {
+	var failing []manifestResult
+	for _, mc := range manifestConditions {
+		if manifestConditionStatus(mc, conditionType) == metav1.ConditionFalse {
+			failing = append(failing, manifestResult{mc.ResourceMeta.Ordinal, mc.ResourceMeta.Resource})
+		}
+	}
+
+	if len(failing) > 0 {
+		return workapiv1.StatusCondition{
+			Type:    conditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  fmt.Sprintf("%sManifestFailed", conditionType),
+			Message: summarizeManifests("failed", len(manifestConditions), failing),
+		}
+	}
+
+	return workapiv1.StatusCondition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  fmt.Sprintf("%sManifestsTrue", conditionType),
+		Message: fmt.Sprintf("All %d manifests are %s", len(manifestConditions), conditionType),
+	}
+}
+
+func aggregatePriority(conditionType string, manifestConditions []workapiv1.ManifestCondition, priority StatusPriority) workapiv1.StatusCondition {
+	rank := make(map[metav1.ConditionStatus]int, len(priority))
+	for i, status := range priority {
+		rank[status] = i
+	}
+
+	winnerRank := -1
+	var winners []manifestResult
+	for _, mc := range manifestConditions {
+		status := manifestConditionStatus(mc, conditionType)
+		r, ok := rank[status]
+		if !ok {
+			continue
+		}
+		switch {
+		case winnerRank == -1 || r < winnerRank:
+			winnerRank = r
+			winners = []manifestResult{{mc.ResourceMeta.Ordinal, mc.ResourceMeta.Resource}}
+		case r == winnerRank:
+			winners = append(winners, manifestResult{mc.ResourceMeta.Ordinal, mc.ResourceMeta.Resource})
+		}
+	}
+
+	if winnerRank == -1 {
+		return workapiv1.StatusCondition{
+			Type:    conditionType,
+			Status:  metav1.ConditionUnknown,
+			Reason:  fmt.Sprintf("%sManifestsUnknown", conditionType),
+			Message: fmt.Sprintf("No manifest reported a status for %s", conditionType),
+		}
+	}
+
+	winningStatus := priority[winnerRank]
+	return workapiv1.StatusCondition{
+		Type:    conditionType,
+		Status:  winningStatus,
+		Reason:  fmt.Sprintf("%sManifest%s", conditionType, winningStatus),
+		Message: summarizeManifests(fmt.Sprintf("report %s", winningStatus), len(manifestConditions), winners),
+	}
+}
+
+// manifestConditionStatus returns the Status of conditionType within mc, or ConditionUnknown if
+// mc does not report that condition type at all.
+func manifestConditionStatus(mc workapiv1.ManifestCondition, conditionType string) metav1.ConditionStatus {
+	cond := findStatusCondition(mc.Conditions, conditionType)
+	if cond == nil {
+		return metav1.ConditionUnknown
+	}
+	return cond.Status
+}
+
+// summarizeManifests renders a deterministic, truncated summary such as
+// "2 of 5 manifests failed: [ordinal=1 resource=deployments], [ordinal=3 resource=services]".
+func summarizeManifests(verb string, total int, results []manifestResult) string {
+	sort.Slice(results, func(i, j int) bool { return results[i].ordinal < results[j].ordinal })
+
+	entries := results
+	truncated := len(entries) > maxAggregateMessageEntries
+	if truncated {
+		entries = entries[:maxAggregateMessageEntries]
+	}
+
+	parts := make([]string, 0, len(entries))
+	for _, r := range entries {
+		parts = append(parts, r.String())
+	}
+
+	msg := fmt.Sprintf("%d of %d manifests %s: %s", len(results), total, verb, strings.Join(parts, ", "))
+	if truncated {
+		msg += fmt.Sprintf(", and %d more", len(results)-len(entries))
+	}
+	return msg
+}
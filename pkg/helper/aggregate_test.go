@@ -0,0 +1,107 @@
+package helper
+
+import (
+	"testing"
+
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+)
+
+// TestAggregateManifestConditionsAllTrue tests AggregateManifestConditions with the default
+// AllTrue merge strategy.
+func TestAggregateManifestConditionsAllTrue(t *testing.T) {
+	cases := []struct {
+		name               string
+		manifestConditions []workapiv1.ManifestCondition
+		expectedStatus     metav1.ConditionStatus
+		expectedReason     string
+		expectedMessage    string
+	}{
+		{
+			name: "all true",
+			manifestConditions: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "deployments", newCondition("Applied", "True", "AppliedManifestComplete", "", nil)),
+				newManifestCondition(1, "services", newCondition("Applied", "True", "AppliedManifestComplete", "", nil)),
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  "AppliedManifestsTrue",
+			expectedMessage: "All 2 manifests are Applied",
+		},
+		{
+			name: "one false",
+			manifestConditions: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "deployments", newCondition("Applied", "True", "AppliedManifestComplete", "", nil)),
+				newManifestCondition(1, "services", newCondition("Applied", "False", "AppliedManifestFailed", "boom", nil)),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "AppliedManifestFailed",
+			expectedMessage: "1 of 2 manifests failed: [ordinal=1 resource=services]",
+		},
+		{
+			name: "missing condition treated as unknown",
+			manifestConditions: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "deployments", newCondition("Applied", "True", "AppliedManifestComplete", "", nil)),
+				newManifestCondition(1, "services"),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "AppliedManifestFailed",
+			expectedMessage: "1 of 2 manifests failed: [ordinal=1 resource=services]",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := AggregateManifestConditions("Applied", c.manifestConditions, AggregateOptions{})
+			assertAggregatedCondition(t, actual, c.expectedStatus, c.expectedReason, c.expectedMessage)
+		})
+	}
+}
+
+// TestAggregateManifestConditionsAnyFalse tests AggregateManifestConditions with the AnyFalse
+// merge strategy.
+func TestAggregateManifestConditionsAnyFalse(t *testing.T) {
+	manifestConditions := []workapiv1.ManifestCondition{
+		newManifestCondition(0, "deployments", newCondition("Available", "Unknown", "AppliedManifestPending", "", nil)),
+		newManifestCondition(1, "services", newCondition("Available", "False", "AvailableManifestFailed", "boom", nil)),
+	}
+
+	actual := AggregateManifestConditions("Available", manifestConditions, AggregateOptions{Strategy: AnyFalseMergeStrategy})
+	assertAggregatedCondition(t, actual, metav1.ConditionFalse, "AvailableManifestFailed", "1 of 2 manifests failed: [ordinal=1 resource=services]")
+
+	manifestConditions = []workapiv1.ManifestCondition{
+		newManifestCondition(0, "deployments", newCondition("Available", "Unknown", "AppliedManifestPending", "", nil)),
+		newManifestCondition(1, "services", newCondition("Available", "True", "AvailableManifestComplete", "", nil)),
+	}
+
+	actual = AggregateManifestConditions("Available", manifestConditions, AggregateOptions{Strategy: AnyFalseMergeStrategy})
+	assertAggregatedCondition(t, actual, metav1.ConditionTrue, "AvailableManifestsTrue", "All 2 manifests are Available")
+}
+
+// TestAggregateManifestConditionsPriority tests AggregateManifestConditions with the Priority
+// merge strategy.
+func TestAggregateManifestConditionsPriority(t *testing.T) {
+	manifestConditions := []workapiv1.ManifestCondition{
+		newManifestCondition(0, "deployments", newCondition("Applied", "True", "AppliedManifestComplete", "", nil)),
+		newManifestCondition(1, "services", newCondition("Applied", "Unknown", "AppliedManifestPending", "", nil)),
+		newManifestCondition(2, "configmaps", newCondition("Applied", "False", "AppliedManifestFailed", "boom", nil)),
+	}
+
+	actual := AggregateManifestConditions("Applied", manifestConditions, AggregateOptions{Strategy: PriorityMergeStrategy})
+	assertAggregatedCondition(t, actual, metav1.ConditionUnknown, "AppliedManifestUnknown", "1 of 3 manifests report Unknown: [ordinal=1 resource=services]")
+}
+
+func assertAggregatedCondition(t *testing.T, actual workapiv1.StatusCondition, status metav1.ConditionStatus, reason, message string) {
+	t.Helper()
+	expected := workapiv1.StatusCondition{
+		Type:    actual.Type,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+	actual.LastTransitionTime = metav1.Time{}
+	if !equality.Semantic.DeepEqual(expected, actual) {
+		t.Errorf(diff.ObjectDiff(expected, actual))
+	}
+}
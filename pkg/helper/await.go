@@ -0,0 +1,319 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	workv1client "github.com/open-cluster-management/api/client/work/clientset/versioned/typed/work/v1"
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// AwaitErrorKind distinguishes why AwaitCondition or AwaitManifestCondition returned without
+// observing the target status.
+type AwaitErrorKind string
+
+const (
+	// AwaitTimedOut means the timeout elapsed before the target condition was observed.
+	AwaitTimedOut AwaitErrorKind = "TimedOut"
+	// AwaitConditionFalse means the condition was observed as False before it could become the
+	// target status (unless the target status is itself False).
+	AwaitConditionFalse AwaitErrorKind = "ConditionFalse"
+	// AwaitObjectDeleted means the ManifestWork was deleted while waiting.
+	AwaitObjectDeleted AwaitErrorKind = "ObjectDeleted"
+)
+
+// AwaitError is returned by AwaitCondition and AwaitManifestCondition when the target condition
+// was not observed.
+type AwaitError struct {
+	Kind          AwaitErrorKind
+	ConditionType string
+	Ordinal       int32
+	Resource      string
+	Reason        string
+	Message       string
+}
+
+func (e *AwaitError) Error() string {
+	switch e.Kind {
+	case AwaitConditionFalse:
+		return fmt.Sprintf("condition %s observed as False: reason=%s message=%s", e.ConditionType, e.Reason, e.Message)
+	case AwaitObjectDeleted:
+		return fmt.Sprintf("manifestwork was deleted while waiting for condition %s", e.ConditionType)
+	default:
+		return fmt.Sprintf("timed out waiting for condition %s", e.ConditionType)
+	}
+}
+
+// ConditionAwaiter lets callers block until a ManifestWork, or one of its per-manifest resources,
+// reaches a target condition. It is backed by one shared watch per ManifestWork rather than
+// polling: concurrent waiters on the same (namespace, name) share a single watch, which is started
+// on first use and stopped once the last waiter releases it.
+type ConditionAwaiter struct {
+	client workv1client.WorkV1Interface
+	clock  clock.Clock
+
+	mu        sync.Mutex
+	watches   map[string]*sharedWatch
+	watchRefs map[string]int
+}
+
+// NewConditionAwaiter returns a ConditionAwaiter backed by client.
+func NewConditionAwaiter(client workv1client.WorkV1Interface) *ConditionAwaiter {
+	return &ConditionAwaiter{
+		client:    client,
+		clock:     clock.RealClock{},
+		watches:   map[string]*sharedWatch{},
+		watchRefs: map[string]int{},
+	}
+}
+
+// AwaitCondition blocks until the ManifestWork namespace/name reports status for conditionType
+// equal to target, the condition is observed as False (unless target itself is False), the
+// ManifestWork is deleted, or timeout elapses.
+func (a *ConditionAwaiter) AwaitCondition(ctx context.Context, namespace, name, conditionType string, target metav1.ConditionStatus, timeout time.Duration) (*workapiv1.ManifestWork, error) {
+	return a.await(ctx, namespace, name, timeout, func(ev watchEvent) (*workapiv1.ManifestWork, error, bool) {
+		if ev.err != nil {
+			return nil, ev.err, true
+		}
+		if ev.deleted {
+			return nil, &AwaitError{Kind: AwaitObjectDeleted, ConditionType: conditionType}, true
+		}
+		cond := findStatusCondition(ev.work.Status.Conditions, conditionType)
+		if cond == nil {
+			return nil, nil, false
+		}
+		switch cond.Status {
+		case target:
+			return ev.work, nil, true
+		case metav1.ConditionFalse:
+			return nil, &AwaitError{Kind: AwaitConditionFalse, ConditionType: conditionType, Reason: cond.Reason, Message: cond.Message}, true
+		default:
+			return nil, nil, false
+		}
+	}, func() error { return &AwaitError{Kind: AwaitTimedOut, ConditionType: conditionType} })
+}
+
+// AwaitManifestCondition blocks until the manifest identified by (ordinal, resource) within the
+// ManifestWork namespace/name reports status for conditionType equal to target, the condition is
+// observed as False (unless target itself is False), the ManifestWork is deleted, or timeout
+// elapses.
+func (a *ConditionAwaiter) AwaitManifestCondition(ctx context.Context, namespace, name string, ordinal int32, resource, conditionType string, target metav1.ConditionStatus, timeout time.Duration) (*workapiv1.ManifestWork, error) {
+	return a.await(ctx, namespace, name, timeout, func(ev watchEvent) (*workapiv1.ManifestWork, error, bool) {
+		if ev.err != nil {
+			return nil, ev.err, true
+		}
+		if ev.deleted {
+			return nil, &AwaitError{Kind: AwaitObjectDeleted, ConditionType: conditionType, Ordinal: ordinal, Resource: resource}, true
+		}
+		mc := findManifestCondition(ev.work.Status.ResourceStatus.Manifests, ordinal, resource)
+		if mc == nil {
+			return nil, nil, false
+		}
+		cond := findStatusCondition(mc.Conditions, conditionType)
+		if cond == nil {
+			return nil, nil, false
+		}
+		switch cond.Status {
+		case target:
+			return ev.work, nil, true
+		case metav1.ConditionFalse:
+			return nil, &AwaitError{Kind: AwaitConditionFalse, ConditionType: conditionType, Ordinal: ordinal, Resource: resource, Reason: cond.Reason, Message: cond.Message}, true
+		default:
+			return nil, nil, false
+		}
+	}, func() error {
+		return &AwaitError{Kind: AwaitTimedOut, ConditionType: conditionType, Ordinal: ordinal, Resource: resource}
+	})
+}
+
+// evaluator inspects a watchEvent and reports the ManifestWork and/or error to return, and whether
+// waiting is done.
+type evaluator func(ev watchEvent) (*workapiv1.ManifestWork, error, bool)
+
+func (a *ConditionAwaiter) await(ctx context.Context, namespace, name string, timeout time.Duration, evaluate evaluator, timeoutErr func() error) (*workapiv1.ManifestWork, error) {
+	w, release := a.acquireWatch(namespace, name)
+	defer release()
+
+	ch, latest := w.subscribe()
+	defer w.unsubscribe(ch)
+
+	if latest != nil {
+		if work, err, done := evaluate(*latest); done {
+			return work, err
+		}
+	}
+
+	timer := a.clock.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C():
+			return nil, timeoutErr()
+		case ev := <-ch:
+			if work, err, done := evaluate(ev); done {
+				return work, err
+			}
+		}
+	}
+}
+
+// acquireWatch returns the shared watch for namespace/name, starting one if this is the first
+// caller, and a release func the caller must call when done waiting. The watch is stopped once
+// the last caller releases it.
+func (a *ConditionAwaiter) acquireWatch(namespace, name string) (*sharedWatch, func()) {
+	key := namespace + "/" + name
+
+	a.mu.Lock()
+	w, ok := a.watches[key]
+	if !ok {
+		w = newSharedWatch(a.client.ManifestWorks(namespace), name)
+		a.watches[key] = w
+	}
+	a.watchRefs[key]++
+	a.mu.Unlock()
+
+	released := false
+	return w, func() {
+		if released {
+			return
+		}
+		released = true
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		a.watchRefs[key]--
+		if a.watchRefs[key] <= 0 {
+			w.cancel()
+			delete(a.watches, key)
+			delete(a.watchRefs, key)
+		}
+	}
+}
+
+// findManifestCondition returns a pointer to the manifest condition matching ordinal and
+// resource, or nil if none is present.
+func findManifestCondition(manifests []workapiv1.ManifestCondition, ordinal int32, resource string) *workapiv1.ManifestCondition {
+	for i := range manifests {
+		if manifests[i].ResourceMeta.Ordinal == ordinal && manifests[i].ResourceMeta.Resource == resource {
+			return &manifests[i]
+		}
+	}
+	return nil
+}
+
+// watchEvent is the latest observed state of a ManifestWork, as broadcast by a sharedWatch. Only
+// one of work/deleted/err is meaningful at a time.
+type watchEvent struct {
+	work    *workapiv1.ManifestWork
+	deleted bool
+	err     error
+}
+
+// sharedWatch runs a single watch against one ManifestWork on behalf of any number of concurrent
+// waiters, broadcasting every observed event and caching the latest one so a new subscriber sees
+// current state immediately instead of racing the next event.
+type sharedWatch struct {
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	latest *watchEvent
+	subs   map[chan watchEvent]struct{}
+}
+
+func newSharedWatch(client workv1client.ManifestWorkInterface, name string) *sharedWatch {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &sharedWatch{
+		cancel: cancel,
+		subs:   map[chan watchEvent]struct{}{},
+	}
+	go w.run(ctx, client, name)
+	return w
+}
+
+func (w *sharedWatch) run(ctx context.Context, client workv1client.ManifestWorkInterface, name string) {
+	opts := metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String()}
+
+	current, err := client.Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		opts.ResourceVersion = current.ResourceVersion
+		w.record(watchEvent{work: current})
+	case apierrors.IsNotFound(err):
+		w.record(watchEvent{deleted: true})
+	default:
+		w.record(watchEvent{err: err})
+		return
+	}
+
+	watcher, err := client.Watch(ctx, opts)
+	if err != nil {
+		w.record(watchEvent{err: err})
+		return
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if work, ok := event.Object.(*workapiv1.ManifestWork); ok {
+					w.record(watchEvent{work: work})
+				}
+			case watch.Deleted:
+				w.record(watchEvent{deleted: true})
+			}
+		}
+	}
+}
+
+func (w *sharedWatch) record(ev watchEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.latest = &ev
+	for ch := range w.subs {
+		// Keep-latest: if the subscriber's buffer is full, drop its oldest buffered event to make
+		// room rather than dropping ev. A waiter that falls behind must still see the most recent
+		// state instead of being stuck on a stale one until timeout.
+		select {
+		case ch <- ev:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- ev:
+			default:
+			}
+		}
+	}
+}
+
+func (w *sharedWatch) subscribe() (chan watchEvent, *watchEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ch := make(chan watchEvent, 16)
+	w.subs[ch] = struct{}{}
+	return ch, w.latest
+}
+
+func (w *sharedWatch) unsubscribe(ch chan watchEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.subs, ch)
+}
@@ -0,0 +1,203 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	fakeworkclient "github.com/open-cluster-management/api/client/work/clientset/versioned/fake"
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func newTestManifestWork(conds ...workapiv1.StatusCondition) *workapiv1.ManifestWork {
+	return &workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+		Status:     workapiv1.ManifestWorkStatus{Conditions: conds},
+	}
+}
+
+// TestAwaitConditionAlreadyMet tests that AwaitCondition returns immediately when the target
+// condition is already present on the ManifestWork.
+func TestAwaitConditionAlreadyMet(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(newTestManifestWork(
+		newCondition("Applied", "True", "AppliedManifestComplete", "", nil),
+	))
+	awaiter := NewConditionAwaiter(fakeWorkClient.WorkV1())
+
+	work, err := awaiter.AwaitCondition(context.TODO(), "cluster1", "work1", "Applied", metav1.ConditionTrue, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if work.Name != "work1" {
+		t.Errorf("unexpected work returned: %v", work)
+	}
+}
+
+// TestAwaitConditionObservedViaWatch tests that AwaitCondition unblocks once an update delivered
+// through the watch satisfies the target condition.
+func TestAwaitConditionObservedViaWatch(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(newTestManifestWork())
+	awaiter := NewConditionAwaiter(fakeWorkClient.WorkV1())
+
+	resultCh := make(chan error, 1)
+	go func() {
+		_, err := awaiter.AwaitCondition(context.TODO(), "cluster1", "work1", "Applied", metav1.ConditionTrue, 5*time.Second)
+		resultCh <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	updated := newTestManifestWork(newCondition("Applied", "True", "AppliedManifestComplete", "", nil))
+	if _, err := fakeWorkClient.WorkV1().ManifestWorks("cluster1").Update(context.TODO(), updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("unexpected err updating manifestwork: %v", err)
+	}
+
+	select {
+	case err := <-resultCh:
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for AwaitCondition to observe the update")
+	}
+}
+
+// TestAwaitConditionFalse tests that AwaitCondition returns a ConditionFalse AwaitError once the
+// condition is observed as False.
+func TestAwaitConditionFalse(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(newTestManifestWork(
+		newCondition("Applied", "False", "AppliedManifestFailed", "boom", nil),
+	))
+	awaiter := NewConditionAwaiter(fakeWorkClient.WorkV1())
+
+	_, err := awaiter.AwaitCondition(context.TODO(), "cluster1", "work1", "Applied", metav1.ConditionTrue, time.Second)
+	awaitErr, ok := err.(*AwaitError)
+	if !ok {
+		t.Fatalf("expected an *AwaitError, got %v", err)
+	}
+	if awaitErr.Kind != AwaitConditionFalse || awaitErr.Reason != "AppliedManifestFailed" {
+		t.Errorf("unexpected error: %+v", awaitErr)
+	}
+}
+
+// TestAwaitConditionObjectDeleted tests that AwaitCondition returns an ObjectDeleted AwaitError
+// once the ManifestWork is deleted.
+func TestAwaitConditionObjectDeleted(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(newTestManifestWork())
+	awaiter := NewConditionAwaiter(fakeWorkClient.WorkV1())
+
+	resultCh := make(chan error, 1)
+	go func() {
+		_, err := awaiter.AwaitCondition(context.TODO(), "cluster1", "work1", "Applied", metav1.ConditionTrue, 5*time.Second)
+		resultCh <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	if err := fakeWorkClient.WorkV1().ManifestWorks("cluster1").Delete(context.TODO(), "work1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("unexpected err deleting manifestwork: %v", err)
+	}
+
+	select {
+	case err := <-resultCh:
+		awaitErr, ok := err.(*AwaitError)
+		if !ok || awaitErr.Kind != AwaitObjectDeleted {
+			t.Fatalf("expected an ObjectDeleted AwaitError, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for AwaitCondition to observe the deletion")
+	}
+}
+
+// TestAwaitConditionTimedOut tests that AwaitCondition returns a TimedOut AwaitError once the
+// timeout elapses, using a fake clock so the test does not actually wait it out.
+func TestAwaitConditionTimedOut(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(newTestManifestWork())
+	awaiter := NewConditionAwaiter(fakeWorkClient.WorkV1())
+	fakeClock := clock.NewFakeClock(time.Now())
+	awaiter.clock = fakeClock
+
+	resultCh := make(chan error, 1)
+	go func() {
+		_, err := awaiter.AwaitCondition(context.TODO(), "cluster1", "work1", "Applied", metav1.ConditionTrue, time.Minute)
+		resultCh <- err
+	}()
+
+	for !fakeClock.HasWaiters() {
+		time.Sleep(time.Millisecond)
+	}
+	fakeClock.Step(time.Minute)
+
+	select {
+	case err := <-resultCh:
+		awaitErr, ok := err.(*AwaitError)
+		if !ok || awaitErr.Kind != AwaitTimedOut {
+			t.Fatalf("expected a TimedOut AwaitError, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for AwaitCondition to time out")
+	}
+}
+
+// TestSharedWatchRecordKeepsLatestOnOverflow tests that sharedWatch.record does not silently drop
+// the most recent event once a subscriber's buffered channel is full: it evicts an older buffered
+// event to make room instead, so a waiter that falls behind during a burst still eventually
+// observes the latest state rather than stalling until timeout.
+func TestSharedWatchRecordKeepsLatestOnOverflow(t *testing.T) {
+	w := &sharedWatch{subs: map[chan watchEvent]struct{}{}}
+	ch, _ := w.subscribe()
+
+	const n = 20 // more than the subscriber channel's buffer size
+	for i := 0; i < n; i++ {
+		work := newTestManifestWork(newCondition("Applied", "Unknown", fmt.Sprintf("step-%d", i), "", nil))
+		w.record(watchEvent{work: work})
+	}
+
+	var last watchEvent
+	for {
+		select {
+		case ev := <-ch:
+			last = ev
+			continue
+		default:
+		}
+		break
+	}
+	if last.work == nil || last.work.Status.Conditions[0].Reason != fmt.Sprintf("step-%d", n-1) {
+		t.Fatalf("expected the most recent event to survive the buffer overflow, got %+v", last)
+	}
+}
+
+// TestAwaitManifestCondition tests that AwaitManifestCondition unblocks once the target manifest
+// reports the target condition.
+func TestAwaitManifestCondition(t *testing.T) {
+	work := newTestManifestWork()
+	work.Status.ResourceStatus.Manifests = []workapiv1.ManifestCondition{
+		newManifestCondition(0, "deployments", newCondition("Applied", "Unknown", "AppliedManifestPending", "", nil)),
+	}
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(work)
+	awaiter := NewConditionAwaiter(fakeWorkClient.WorkV1())
+
+	resultCh := make(chan error, 1)
+	go func() {
+		_, err := awaiter.AwaitManifestCondition(context.TODO(), "cluster1", "work1", 0, "deployments", "Applied", metav1.ConditionTrue, 5*time.Second)
+		resultCh <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	updated := work.DeepCopy()
+	updated.Status.ResourceStatus.Manifests[0].Conditions[0] = newCondition("Applied", "True", "AppliedManifestComplete", "", nil)
+	if _, err := fakeWorkClient.WorkV1().ManifestWorks("cluster1").Update(context.TODO(), updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("unexpected err updating manifestwork: %v", err)
+	}
+
+	select {
+	case err := <-resultCh:
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for AwaitManifestCondition to observe the update")
+	}
+}
@@ -0,0 +1,145 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	workv1client "github.com/open-cluster-management/api/client/work/clientset/versioned/typed/work/v1"
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/record"
+)
+
+// dedupWindow bounds how long EventingUpdater suppresses repeat events for the same ManifestWork's
+// (ordinal, resource, conditionType) transition into a given status, so a condition flapping within
+// a short window produces one event per status it flaps into instead of one per flap. EventingUpdater is long-lived and shared
+// across ManifestWorks, so the dedup key is always scoped by the ManifestWork's namespace/name too:
+// two different ManifestWorks transitioning the same condition at the same time must each get an
+// event.
+const dedupWindow = 10 * time.Second
+
+// EventingUpdater wraps UpdateManifestWorkStatusWithOptions so that every true condition
+// transition a status update persists — top-level or per-manifest — also produces a Recorder
+// event on the ManifestWork. A transition is a change in Status or Reason; message-only or
+// ObservedGeneration-only changes do not produce events.
+type EventingUpdater struct {
+	Recorder record.EventRecorder
+	Clock    clock.Clock
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewEventingUpdater returns an EventingUpdater that records events via recorder.
+func NewEventingUpdater(recorder record.EventRecorder) *EventingUpdater {
+	return &EventingUpdater{
+		Recorder: recorder,
+		Clock:    clock.RealClock{},
+		seen:     map[string]time.Time{},
+	}
+}
+
+// UpdateManifestWorkStatus behaves like UpdateManifestWorkStatus, but also emits a Recorder event
+// for every true condition transition the update persists.
+func (u *EventingUpdater) UpdateManifestWorkStatus(
+	ctx context.Context,
+	client workv1client.ManifestWorkInterface,
+	name string,
+	updateFuncs ...UpdateManifestWorkStatusFunc) (*workapiv1.ManifestWorkStatus, bool, error) {
+	return UpdateManifestWorkStatusWithOptions(ctx, client, name, updateFuncs, WithTransitionObserver(u.emit))
+}
+
+func (u *EventingUpdater) emit(work *workapiv1.ManifestWork, oldStatus, newStatus workapiv1.ManifestWorkStatus) {
+	for _, t := range diffStatusConditions(oldStatus.Conditions, newStatus.Conditions) {
+		key := fmt.Sprintf("%s/%s/%s/%s", work.Namespace, work.Name, t.conditionType, t.newStatus)
+		u.record(work, key, t)
+	}
+	for _, t := range diffManifestConditions(oldStatus.ResourceStatus.Manifests, newStatus.ResourceStatus.Manifests) {
+		key := fmt.Sprintf("%s/%s/%d/%s/%s/%s", work.Namespace, work.Name, t.ordinal, t.resource, t.conditionType, t.newStatus)
+		u.record(work, key, t.conditionTransition)
+	}
+}
+
+func (u *EventingUpdater) record(work *workapiv1.ManifestWork, dedupKey string, t conditionTransition) {
+	u.mu.Lock()
+	now := u.Clock.Now()
+	if last, ok := u.seen[dedupKey]; ok && now.Sub(last) < dedupWindow {
+		u.mu.Unlock()
+		return
+	}
+	u.seen[dedupKey] = now
+	u.mu.Unlock()
+
+	eventType := corev1.EventTypeNormal
+	if t.newStatus != metav1.ConditionTrue {
+		eventType = corev1.EventTypeWarning
+	}
+	u.Recorder.Eventf(work, eventType, fmt.Sprintf("%s%s", t.conditionType, t.newStatus),
+		"%s changed from %s to %s: %s %s", t.conditionType, t.oldStatus, t.newStatus, t.reason, t.message)
+}
+
+// conditionTransition describes a StatusCondition whose Status or Reason changed.
+type conditionTransition struct {
+	conditionType        string
+	oldStatus, newStatus metav1.ConditionStatus
+	reason, message      string
+}
+
+// diffStatusConditions returns a transition for every condition in newConds whose Status or
+// Reason differs from its counterpart (matched by Type) in oldConds. A condition present only in
+// newConds is reported with an empty oldStatus.
+func diffStatusConditions(oldConds, newConds []workapiv1.StatusCondition) []conditionTransition {
+	var transitions []conditionTransition
+	for i := range newConds {
+		newCond := newConds[i]
+		oldCond := findStatusCondition(oldConds, newCond.Type)
+		if oldCond != nil && oldCond.Status == newCond.Status && oldCond.Reason == newCond.Reason {
+			continue
+		}
+		var oldStatus metav1.ConditionStatus
+		if oldCond != nil {
+			oldStatus = oldCond.Status
+		}
+		transitions = append(transitions, conditionTransition{
+			conditionType: newCond.Type,
+			oldStatus:     oldStatus,
+			newStatus:     newCond.Status,
+			reason:        newCond.Reason,
+			message:       newCond.Message,
+		})
+	}
+	return transitions
+}
+
+// manifestConditionTransition is a conditionTransition scoped to a single manifest.
+type manifestConditionTransition struct {
+	conditionTransition
+	ordinal  int32
+	resource string
+}
+
+// diffManifestConditions walks newManifests and returns a manifestConditionTransition for every
+// per-manifest condition whose Status or Reason changed relative to oldManifests, matched by
+// (ordinal, resource).
+func diffManifestConditions(oldManifests, newManifests []workapiv1.ManifestCondition) []manifestConditionTransition {
+	var transitions []manifestConditionTransition
+	for i := range newManifests {
+		newManifest := newManifests[i]
+		var oldConds []workapiv1.StatusCondition
+		if oldManifest := findManifestCondition(oldManifests, newManifest.ResourceMeta.Ordinal, newManifest.ResourceMeta.Resource); oldManifest != nil {
+			oldConds = oldManifest.Conditions
+		}
+		for _, t := range diffStatusConditions(oldConds, newManifest.Conditions) {
+			transitions = append(transitions, manifestConditionTransition{
+				conditionTransition: t,
+				ordinal:             newManifest.ResourceMeta.Ordinal,
+				resource:            newManifest.ResourceMeta.Resource,
+			})
+		}
+	}
+	return transitions
+}
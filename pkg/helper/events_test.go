@@ -0,0 +1,188 @@
+package helper
+
+import (
+	"context"
+	"testing"
+
+	fakeworkclient "github.com/open-cluster-management/api/client/work/clientset/versioned/fake"
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// TestEventingUpdaterEmitsOnTransition tests that EventingUpdater.UpdateManifestWorkStatus emits
+// exactly one event per true condition transition, and none for message-only changes, mirroring
+// the cases in TestUpdateStatusCondition.
+func TestEventingUpdaterEmitsOnTransition(t *testing.T) {
+	cases := []struct {
+		name               string
+		startingConditions []workapiv1.StatusCondition
+		newCondition       workapiv1.StatusCondition
+		expectedEvents     []string
+	}{
+		{
+			name:               "add to empty",
+			startingConditions: []workapiv1.StatusCondition{},
+			newCondition:       newCondition("test", "True", "my-reason", "my-message", nil),
+			expectedEvents:     []string{"Normal testTrue test changed from  to True: my-reason my-message"},
+		},
+		{
+			name: "change existing status",
+			startingConditions: []workapiv1.StatusCondition{
+				newCondition("one", "True", "my-reason", "my-message", nil),
+			},
+			newCondition:   newCondition("one", "False", "my-different-reason", "my-othermessage", nil),
+			expectedEvents: []string{"Warning oneFalse one changed from True to False: my-different-reason my-othermessage"},
+		},
+		{
+			name: "message-only change produces no event",
+			startingConditions: []workapiv1.StatusCondition{
+				newCondition("one", "True", "my-reason", "my-message", nil),
+			},
+			newCondition:   newCondition("one", "True", "my-reason", "my-new-message", nil),
+			expectedEvents: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+				ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+				Status:     workapiv1.ManifestWorkStatus{Conditions: c.startingConditions},
+			})
+			recorder := record.NewFakeRecorder(10)
+			updater := NewEventingUpdater(recorder)
+
+			_, _, err := updater.UpdateManifestWorkStatus(
+				context.TODO(),
+				fakeWorkClient.WorkV1().ManifestWorks("cluster1"),
+				"work1",
+				updateSpokeClusterConditionFn(c.newCondition),
+			)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			close(recorder.Events)
+
+			var events []string
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+			if len(events) != len(c.expectedEvents) {
+				t.Fatalf("expected events %v, got %v", c.expectedEvents, events)
+			}
+			for i := range events {
+				if events[i] != c.expectedEvents[i] {
+					t.Errorf("expected event %q, got %q", c.expectedEvents[i], events[i])
+				}
+			}
+		})
+	}
+}
+
+// TestEventingUpdaterEmitsOnManifestTransition tests that EventingUpdater.UpdateManifestWorkStatus
+// emits an event for a per-manifest condition transition, mirroring the top-level table test.
+func TestEventingUpdaterEmitsOnManifestTransition(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+		Status: workapiv1.ManifestWorkStatus{
+			ResourceStatus: workapiv1.ManifestResourceStatus{
+				Manifests: []workapiv1.ManifestCondition{
+					newManifestCondition(0, "resource1", newCondition("Applied", "False", "AppliedManifestPending", "", nil)),
+				},
+			},
+		},
+	})
+	recorder := record.NewFakeRecorder(10)
+	updater := NewEventingUpdater(recorder)
+
+	newManifestCond := newManifestCondition(0, "resource1", newCondition("Applied", "True", "AppliedManifestComplete", "", nil))
+	_, _, err := updater.UpdateManifestWorkStatus(
+		context.TODO(),
+		fakeWorkClient.WorkV1().ManifestWorks("cluster1"),
+		"work1",
+		func(status *workapiv1.ManifestWorkStatus) error {
+			SetManifestCondition(&status.ResourceStatus.Manifests, newManifestCond)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	close(recorder.Events)
+
+	var events []string
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+	expectedEvents := []string{"Normal AppliedTrue Applied changed from False to True: AppliedManifestComplete "}
+	if len(events) != len(expectedEvents) {
+		t.Fatalf("expected events %v, got %v", expectedEvents, events)
+	}
+	for i := range events {
+		if events[i] != expectedEvents[i] {
+			t.Errorf("expected event %q, got %q", expectedEvents[i], events[i])
+		}
+	}
+}
+
+// TestEventingUpdaterDedupIsPerManifestWork tests that the dedup window only suppresses repeat
+// events for the same ManifestWork: two different ManifestWorks transitioning the same condition
+// type into the same status at the same time must each get their own event.
+func TestEventingUpdaterDedupIsPerManifestWork(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(
+		&workapiv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"}},
+		&workapiv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{Name: "work2", Namespace: "cluster1"}},
+	)
+	recorder := record.NewFakeRecorder(10)
+	updater := NewEventingUpdater(recorder)
+	client := fakeWorkClient.WorkV1().ManifestWorks("cluster1")
+
+	cond := newCondition("Applied", "True", "my-reason", "my-message", nil)
+	if _, _, err := updater.UpdateManifestWorkStatus(context.TODO(), client, "work1", updateSpokeClusterConditionFn(cond)); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if _, _, err := updater.UpdateManifestWorkStatus(context.TODO(), client, "work2", updateSpokeClusterConditionFn(cond)); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	close(recorder.Events)
+
+	var events []string
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected one event per ManifestWork (2 total), got %v", events)
+	}
+}
+
+// TestEventingUpdaterDedupesFlapping tests that a condition flapping within the dedup window only
+// produces a single event for its first transition into a given status.
+func TestEventingUpdaterDedupesFlapping(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+	})
+	recorder := record.NewFakeRecorder(10)
+	updater := NewEventingUpdater(recorder)
+	client := fakeWorkClient.WorkV1().ManifestWorks("cluster1")
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := updater.UpdateManifestWorkStatus(context.TODO(), client, "work1",
+			updateSpokeClusterConditionFn(newCondition("test", "False", "my-reason", "my-message", nil))); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if _, _, err := updater.UpdateManifestWorkStatus(context.TODO(), client, "work1",
+			updateSpokeClusterConditionFn(newCondition("test", "True", "my-reason", "my-message", nil))); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+	close(recorder.Events)
+
+	var events []string
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected exactly 2 events (one per status flapped into), got %v", events)
+	}
+}
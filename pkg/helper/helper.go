@@ -0,0 +1,64 @@
+package helper
+
+import (
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetStatusCondition sets the corresponding condition in conditions to newCondition, replacing any
+// existing condition with the same Type. LastTransitionTime is only bumped when Status changes.
+func SetStatusCondition(conditions *[]workapiv1.StatusCondition, newCondition workapiv1.StatusCondition) {
+	if conditions == nil {
+		return
+	}
+
+	existingCondition := findStatusCondition(*conditions, newCondition.Type)
+	if existingCondition == nil {
+		if newCondition.LastTransitionTime.IsZero() {
+			newCondition.LastTransitionTime = metav1.Now()
+		}
+		*conditions = append(*conditions, newCondition)
+		return
+	}
+
+	if existingCondition.Status != newCondition.Status {
+		existingCondition.Status = newCondition.Status
+		if !newCondition.LastTransitionTime.IsZero() {
+			existingCondition.LastTransitionTime = newCondition.LastTransitionTime
+		} else {
+			existingCondition.LastTransitionTime = metav1.Now()
+		}
+	}
+
+	existingCondition.Reason = newCondition.Reason
+	existingCondition.Message = newCondition.Message
+}
+
+// SetManifestCondition sets the manifest condition whose ResourceMeta.Ordinal matches
+// newManifestCondition's to newManifestCondition, replacing it wholesale. If no existing manifest
+// condition has that ordinal, newManifestCondition is appended.
+func SetManifestCondition(conditions *[]workapiv1.ManifestCondition, newManifestCondition workapiv1.ManifestCondition) {
+	if conditions == nil {
+		return
+	}
+
+	for i := range *conditions {
+		if (*conditions)[i].ResourceMeta.Ordinal == newManifestCondition.ResourceMeta.Ordinal {
+			(*conditions)[i] = newManifestCondition
+			return
+		}
+	}
+
+	*conditions = append(*conditions, newManifestCondition)
+}
+
+// findStatusCondition returns a pointer to the condition of the given type in conditions, or nil
+// if none is present.
+func findStatusCondition(conditions []workapiv1.StatusCondition, conditionType string) *workapiv1.StatusCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
@@ -26,6 +26,12 @@ func newCondition(name, status, reason, message string, lastTransition *metav1.T
 	return ret
 }
 
+func newConditionWithGeneration(name, status, reason, message string, observedGeneration int64, lastTransition *metav1.Time) workapiv1.StatusCondition {
+	ret := newCondition(name, status, reason, message, lastTransition)
+	ret.ObservedGeneration = observedGeneration
+	return ret
+}
+
 func updateSpokeClusterConditionFn(cond workapiv1.StatusCondition) UpdateManifestWorkStatusFunc {
 	return func(oldStatus *workapiv1.ManifestWorkStatus) error {
 		SetStatusCondition(&oldStatus.Conditions, cond)
@@ -48,6 +54,7 @@ func TestUpdateStatusCondition(t *testing.T) {
 
 	cases := []struct {
 		name               string
+		workGeneration     int64
 		startingConditions []workapiv1.StatusCondition
 		newCondition       workapiv1.StatusCondition
 		expectedUpdated    bool
@@ -98,12 +105,24 @@ func TestUpdateStatusCondition(t *testing.T) {
 				newCondition("one", "True", "my-reason", "my-message", &beforeish),
 			},
 		},
+		{
+			name:           "generation bump advances observed generation",
+			workGeneration: 2,
+			startingConditions: []workapiv1.StatusCondition{
+				newConditionWithGeneration("one", "True", "my-reason", "my-message", 1, &beforeish),
+			},
+			newCondition:    newCondition("one", "True", "my-reason", "my-message", nil),
+			expectedUpdated: true,
+			expectedConditions: []workapiv1.StatusCondition{
+				newConditionWithGeneration("one", "True", "my-reason", "my-message", 2, &beforeish),
+			},
+		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
-				ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1", Generation: c.workGeneration},
 				Status: workapiv1.ManifestWorkStatus{
 					Conditions: c.startingConditions,
 				},
@@ -0,0 +1,176 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	workv1client "github.com/open-cluster-management/api/client/work/clientset/versioned/typed/work/v1"
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// UpdateManifestWorkStatusFunc applies a mutation to a ManifestWorkStatus. It is called with a
+// copy of the current status and may return an error to abort the update.
+type UpdateManifestWorkStatusFunc func(status *workapiv1.ManifestWorkStatus) error
+
+// defaultConflictMaxDelay is the ceiling the default rate limiter backs off to once a caller has
+// retried a single ManifestWork's status update often enough to fall out of the fast path.
+const defaultConflictMaxDelay = 5 * time.Second
+
+// updateManifestWorkStatusConfig holds the tunables for UpdateManifestWorkStatusWithOptions.
+type updateManifestWorkStatusConfig struct {
+	rateLimiter workqueue.RateLimiter
+	maxAttempts int
+	clock       clock.Clock
+	observer    func(work *workapiv1.ManifestWork, oldStatus, newStatus workapiv1.ManifestWorkStatus)
+}
+
+// UpdateManifestWorkStatusOption customizes UpdateManifestWorkStatusWithOptions.
+type UpdateManifestWorkStatusOption func(*updateManifestWorkStatusConfig)
+
+// WithRateLimiter overrides the default per-ManifestWork conflict-backoff rate limiter.
+func WithRateLimiter(rateLimiter workqueue.RateLimiter) UpdateManifestWorkStatusOption {
+	return func(c *updateManifestWorkStatusConfig) {
+		c.rateLimiter = rateLimiter
+	}
+}
+
+// WithMaxAttempts caps the total number of update attempts, the first one included, before
+// UpdateManifestWorkStatusWithOptions gives up on conflicts and returns an error. A non-positive
+// value (the default) means unlimited retries.
+func WithMaxAttempts(maxAttempts int) UpdateManifestWorkStatusOption {
+	return func(c *updateManifestWorkStatusConfig) {
+		c.maxAttempts = maxAttempts
+	}
+}
+
+// WithClock overrides the clock used to wait out the backoff between conflict retries. It exists
+// so tests can assert on the computed backoff without actually sleeping.
+func WithClock(c clock.Clock) UpdateManifestWorkStatusOption {
+	return func(cfg *updateManifestWorkStatusConfig) {
+		cfg.clock = c
+	}
+}
+
+// WithTransitionObserver registers a callback invoked just after
+// UpdateManifestWorkStatusWithOptions successfully persists a change, with the ManifestWork, its
+// status before updateFuncs ran and the status as actually written. It is not called when the
+// computed status turns out to be unchanged, nor on an attempt that loses to a write conflict and
+// is retried, so it never reports a transition that did not actually land. EventingUpdater uses
+// this to emit events on condition transitions.
+func WithTransitionObserver(observer func(work *workapiv1.ManifestWork, oldStatus, newStatus workapiv1.ManifestWorkStatus)) UpdateManifestWorkStatusOption {
+	return func(c *updateManifestWorkStatusConfig) {
+		c.observer = observer
+	}
+}
+
+// NewConflictRateLimiter returns the rate limiter UpdateManifestWorkStatus uses by default: a
+// per-ManifestWork fast-slow backoff (20 near-immediate retries at 50ms, then maxDelay) combined
+// with a token-bucket cap of 5 updates/sec and a burst of 20. UpdateManifestWorkStatusWithOptions
+// builds a new limiter for every call unless one is passed via WithRateLimiter, so by default the
+// cap applies to the retries of a single call only; it is not shared across calls.
+func NewConflictRateLimiter(maxDelay time.Duration) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemFastSlowRateLimiter(50*time.Millisecond, maxDelay, 20),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(5), 20)},
+	)
+}
+
+// UpdateManifestWorkStatus fetches the named ManifestWork, applies updateFuncs in order to a copy
+// of its status and, if the result differs from what is currently stored, writes it back via the
+// status subresource, retrying on write conflicts with the default conflict rate limiter. See
+// UpdateManifestWorkStatusWithOptions for details and for how to customize the retry behavior.
+func UpdateManifestWorkStatus(
+	ctx context.Context,
+	client workv1client.ManifestWorkInterface,
+	name string,
+	updateFuncs ...UpdateManifestWorkStatusFunc) (*workapiv1.ManifestWorkStatus, bool, error) {
+	return UpdateManifestWorkStatusWithOptions(ctx, client, name, updateFuncs)
+}
+
+// UpdateManifestWorkStatusWithOptions behaves like UpdateManifestWorkStatus, except the rate
+// limiter, retry cap and clock used to back off between conflicts can be customized via opts. On
+// each conflict it re-fetches the ManifestWork and re-runs updateFuncs from scratch, so callers
+// always compute their update against the latest resourceVersion. It returns once the update
+// succeeds, ctx is done, a non-conflict error occurs, or the optional attempt cap is exceeded.
+// Every condition in the resulting status is stamped with the ManifestWork's current Generation as
+// its ObservedGeneration, so a generation bump alone — with Status, Reason and Message otherwise
+// unchanged — is still treated as a meaningful update.
+func UpdateManifestWorkStatusWithOptions(
+	ctx context.Context,
+	client workv1client.ManifestWorkInterface,
+	name string,
+	updateFuncs []UpdateManifestWorkStatusFunc,
+	opts ...UpdateManifestWorkStatusOption) (*workapiv1.ManifestWorkStatus, bool, error) {
+	cfg := &updateManifestWorkStatusConfig{
+		rateLimiter: NewConflictRateLimiter(defaultConflictMaxDelay),
+		clock:       clock.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	defer cfg.rateLimiter.Forget(name)
+
+	for attempt := 0; ; attempt++ {
+		if cfg.maxAttempts > 0 && attempt >= cfg.maxAttempts {
+			return nil, false, fmt.Errorf("exceeded %d attempts updating status of manifestwork %q on conflict", cfg.maxAttempts, name)
+		}
+
+		manifestWork, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, false, err
+		}
+
+		oldStatus := manifestWork.Status
+		newStatus := oldStatus.DeepCopy()
+		for _, update := range updateFuncs {
+			if err := update(newStatus); err != nil {
+				return nil, false, err
+			}
+		}
+		stampObservedGeneration(newStatus, manifestWork.Generation)
+
+		if equality.Semantic.DeepEqual(oldStatus, *newStatus) {
+			return newStatus, false, nil
+		}
+
+		manifestWork.Status = *newStatus
+		updatedManifestWork, err := client.UpdateStatus(ctx, manifestWork, metav1.UpdateOptions{})
+		switch {
+		case err == nil:
+			if cfg.observer != nil {
+				cfg.observer(manifestWork, oldStatus, updatedManifestWork.Status)
+			}
+			return &updatedManifestWork.Status, true, nil
+		case apierrors.IsConflict(err):
+			select {
+			case <-ctx.Done():
+				return nil, false, ctx.Err()
+			case <-cfg.clock.After(cfg.rateLimiter.When(name)):
+			}
+		default:
+			return nil, false, err
+		}
+	}
+}
+
+// stampObservedGeneration records generation as the ObservedGeneration of every condition in
+// status, top-level and per-manifest alike, mirroring the meaning metav1.Condition.ObservedGeneration
+// has upstream: the generation of the ManifestWork spec that this status was computed against.
+func stampObservedGeneration(status *workapiv1.ManifestWorkStatus, generation int64) {
+	for i := range status.Conditions {
+		status.Conditions[i].ObservedGeneration = generation
+	}
+	for i := range status.ResourceStatus.Manifests {
+		conditions := status.ResourceStatus.Manifests[i].Conditions
+		for j := range conditions {
+			conditions[j].ObservedGeneration = generation
+		}
+	}
+}
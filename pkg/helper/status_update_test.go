@@ -0,0 +1,256 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	fakeworkclient "github.com/open-cluster-management/api/client/work/clientset/versioned/fake"
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/clock"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// recordingClock satisfies clock.Clock by delegating to a real clock but, instead of actually
+// sleeping, accumulates every requested duration and fires immediately. This lets tests assert on
+// the total simulated backoff without taking as long to run.
+type recordingClock struct {
+	clock.Clock
+	mu    sync.Mutex
+	total time.Duration
+}
+
+func newRecordingClock() *recordingClock {
+	return &recordingClock{Clock: clock.RealClock{}}
+}
+
+func (c *recordingClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.total += d
+	c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	ch <- c.Clock.Now()
+	return ch
+}
+
+func (c *recordingClock) totalDelay() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.total
+}
+
+// TestUpdateManifestWorkStatusWithOptionsConflictRetry tests that UpdateManifestWorkStatusWithOptions
+// retries on conflict, re-fetching and re-applying updateFuncs each time, until the update succeeds.
+func TestUpdateManifestWorkStatusWithOptionsConflictRetry(t *testing.T) {
+	const wantConflicts = 3
+
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+	})
+
+	attempts := 0
+	fakeWorkClient.PrependReactor("update", "manifestworks", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		updateAction, ok := action.(clienttesting.UpdateAction)
+		if !ok || updateAction.GetSubresource() != "status" {
+			return false, nil, nil
+		}
+		attempts++
+		if attempts <= wantConflicts {
+			gr := schema.GroupResource{Group: "work.open-cluster-management.io", Resource: "manifestworks"}
+			return true, nil, apierrors.NewConflict(gr, "work1", fmt.Errorf("resourceVersion conflict"))
+		}
+		return false, nil, nil
+	})
+
+	rc := newRecordingClock()
+	cond := newCondition("test", "True", "my-reason", "my-message", nil)
+
+	status, updated, err := UpdateManifestWorkStatusWithOptions(
+		context.TODO(),
+		fakeWorkClient.WorkV1().ManifestWorks("cluster1"),
+		"work1",
+		[]UpdateManifestWorkStatusFunc{updateSpokeClusterConditionFn(cond)},
+		WithClock(rc),
+		WithRateLimiter(NewConflictRateLimiter(time.Second)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !updated {
+		t.Errorf("expected status to be reported as updated")
+	}
+	if attempts != wantConflicts+1 {
+		t.Errorf("expected %d attempts, got %d", wantConflicts+1, attempts)
+	}
+	if want := time.Duration(wantConflicts) * 50 * time.Millisecond; rc.totalDelay() != want {
+		t.Errorf("expected total simulated delay %s, got %s", want, rc.totalDelay())
+	}
+	if len(status.Conditions) != 1 || status.Conditions[0].Type != "test" {
+		t.Errorf("unexpected resulting conditions: %v", status.Conditions)
+	}
+}
+
+// TestUpdateManifestWorkStatusStampsManifestObservedGeneration tests that
+// UpdateManifestWorkStatusWithOptions stamps the ManifestWork's Generation onto per-manifest
+// conditions, not just top-level ones.
+func TestUpdateManifestWorkStatusStampsManifestObservedGeneration(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1", Generation: 3},
+		Status: workapiv1.ManifestWorkStatus{
+			ResourceStatus: workapiv1.ManifestResourceStatus{
+				Manifests: []workapiv1.ManifestCondition{
+					newManifestCondition(0, "resource1", newConditionWithGeneration("one", "True", "my-reason", "my-message", 2, nil)),
+				},
+			},
+		},
+	})
+
+	cond := newManifestCondition(0, "resource1", newCondition("one", "True", "my-reason", "my-new-message", nil))
+	status, _, err := UpdateManifestWorkStatusWithOptions(
+		context.TODO(),
+		fakeWorkClient.WorkV1().ManifestWorks("cluster1"),
+		"work1",
+		[]UpdateManifestWorkStatusFunc{func(s *workapiv1.ManifestWorkStatus) error {
+			SetManifestCondition(&s.ResourceStatus.Manifests, cond)
+			return nil
+		}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(status.ResourceStatus.Manifests) != 1 || len(status.ResourceStatus.Manifests[0].Conditions) != 1 {
+		t.Fatalf("unexpected resulting manifests: %v", status.ResourceStatus.Manifests)
+	}
+	if got := status.ResourceStatus.Manifests[0].Conditions[0].ObservedGeneration; got != 3 {
+		t.Errorf("expected manifest condition ObservedGeneration to be stamped to 3, got %d", got)
+	}
+}
+
+// TestUpdateManifestWorkStatusObserverFiresAfterPersist tests that WithTransitionObserver's
+// callback is only invoked once the update has actually been persisted, and is passed the status
+// as written rather than the pre-write computed copy.
+func TestUpdateManifestWorkStatusObserverFiresAfterPersist(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+	})
+
+	var observed []workapiv1.ManifestWorkStatus
+	cond := newCondition("Applied", "True", "my-reason", "my-message", nil)
+	status, updated, err := UpdateManifestWorkStatusWithOptions(
+		context.TODO(),
+		fakeWorkClient.WorkV1().ManifestWorks("cluster1"),
+		"work1",
+		[]UpdateManifestWorkStatusFunc{updateSpokeClusterConditionFn(cond)},
+		WithTransitionObserver(func(work *workapiv1.ManifestWork, oldStatus, newStatus workapiv1.ManifestWorkStatus) {
+			observed = append(observed, newStatus)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !updated {
+		t.Fatalf("expected status to be reported as updated")
+	}
+	if len(observed) != 1 {
+		t.Fatalf("expected exactly one observer call, got %d", len(observed))
+	}
+	if !equality.Semantic.DeepEqual(observed[0], *status) {
+		t.Errorf("expected observer to see the persisted status %v, got %v", status, observed[0])
+	}
+}
+
+// TestUpdateManifestWorkStatusObserverSkipsConflictedAttempt tests that the transition observer is
+// not invoked for an attempt that loses a write conflict, even if that attempt computed what looked
+// like a real transition — only to discover, once retried against the winning writer's state, that
+// there was nothing left to persist.
+func TestUpdateManifestWorkStatusObserverSkipsConflictedAttempt(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+		Status: workapiv1.ManifestWorkStatus{
+			Conditions: []workapiv1.StatusCondition{newCondition("Applied", "False", "my-reason", "my-message", nil)},
+		},
+	})
+	client := fakeWorkClient.WorkV1().ManifestWorks("cluster1")
+
+	conflicted := false
+	fakeWorkClient.PrependReactor("update", "manifestworks", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		updateAction, ok := action.(clienttesting.UpdateAction)
+		if !ok || updateAction.GetSubresource() != "status" || conflicted {
+			return false, nil, nil
+		}
+		conflicted = true
+
+		// Simulate a concurrent writer winning the race: by the time this attempt is retried, the
+		// object is already in the target state. The winner is written straight to the tracker,
+		// since the fake client holds its lock while reactors run.
+		winner := updateAction.GetObject().(*workapiv1.ManifestWork).DeepCopy()
+		winner.Status.Conditions = []workapiv1.StatusCondition{newCondition("Applied", "True", "my-reason", "my-message", nil)}
+		gvr := schema.GroupVersionResource{Group: "work.open-cluster-management.io", Version: "v1", Resource: "manifestworks"}
+		if err := fakeWorkClient.Tracker().Update(gvr, winner, "cluster1"); err != nil {
+			t.Fatalf("unexpected err simulating concurrent writer: %v", err)
+		}
+
+		gr := schema.GroupResource{Group: "work.open-cluster-management.io", Resource: "manifestworks"}
+		return true, nil, apierrors.NewConflict(gr, "work1", fmt.Errorf("resourceVersion conflict"))
+	})
+
+	var observed []workapiv1.ManifestWorkStatus
+	cond := newCondition("Applied", "True", "my-reason", "my-message", nil)
+	_, updated, err := UpdateManifestWorkStatusWithOptions(
+		context.TODO(),
+		client,
+		"work1",
+		[]UpdateManifestWorkStatusFunc{updateSpokeClusterConditionFn(cond)},
+		WithClock(newRecordingClock()),
+		WithRateLimiter(NewConflictRateLimiter(time.Second)),
+		WithTransitionObserver(func(work *workapiv1.ManifestWork, oldStatus, newStatus workapiv1.ManifestWorkStatus) {
+			observed = append(observed, newStatus)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if updated {
+		t.Errorf("expected no further update once the conflicting attempt's target state was already persisted")
+	}
+	if len(observed) != 0 {
+		t.Errorf("expected the observer to never fire for the conflicted attempt, got %v", observed)
+	}
+}
+
+// TestUpdateManifestWorkStatusWithOptionsMaxAttempts tests that UpdateManifestWorkStatusWithOptions
+// gives up once WithMaxAttempts is exhausted.
+func TestUpdateManifestWorkStatusWithOptionsMaxAttempts(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+	})
+
+	fakeWorkClient.PrependReactor("update", "manifestworks", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		updateAction, ok := action.(clienttesting.UpdateAction)
+		if !ok || updateAction.GetSubresource() != "status" {
+			return false, nil, nil
+		}
+		gr := schema.GroupResource{Group: "work.open-cluster-management.io", Resource: "manifestworks"}
+		return true, nil, apierrors.NewConflict(gr, "work1", fmt.Errorf("resourceVersion conflict"))
+	})
+
+	cond := newCondition("test", "True", "my-reason", "my-message", nil)
+	_, _, err := UpdateManifestWorkStatusWithOptions(
+		context.TODO(),
+		fakeWorkClient.WorkV1().ManifestWorks("cluster1"),
+		"work1",
+		[]UpdateManifestWorkStatusFunc{updateSpokeClusterConditionFn(cond)},
+		WithClock(newRecordingClock()),
+		WithRateLimiter(NewConflictRateLimiter(time.Second)),
+		WithMaxAttempts(2),
+	)
+	if err == nil {
+		t.Fatal("expected an error once max attempts was exceeded")
+	}
+}